@@ -4,13 +4,21 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
+	"reflect"
+	"time"
 
 	"github.com/rs/zerolog/log"
+	"gopkg.in/yaml.v2"
 
 	"gitlab.test.igdcs.com/finops/nextgen/utils/basics/igconfig.git/v2/internal"
 	"gitlab.test.igdcs.com/finops/nextgen/utils/basics/igconfig.git/v2/loader"
 )
 
+// DefaultStatus records the outcome of every loader run made through
+// LoadWithLoadersWithContext. See StatusHandler to expose it over HTTP.
+var DefaultStatus = loader.NewStatus()
+
 var DefaultLoaders = [...]loader.Loader{
 	&loader.Default{},
 	&loader.Consul{},
@@ -36,7 +44,15 @@ func LoadWithLoaders(appName string, configStruct interface{}, loaders ...loader
 }
 
 // LoadWithLoadersWithContext uses provided Loader's to fill 'configStruct'.
+//
+// Loaders no longer overwrite 'configStruct' directly: each loader fills a fresh, zero
+// value of the same type, which is then combined into 'configStruct' using
+// DefaultMergeStrategy. This means a later loader that has no value for a field will not
+// wipe out a value an earlier loader set for it, and maps/structs are merged key by key
+// instead of being replaced wholesale.
 func LoadWithLoadersWithContext(ctx context.Context, appName string, configStruct interface{}, loaders ...loader.Loader) error {
+	structType := reflect.TypeOf(configStruct).Elem()
+
 	for _, configLoader := range loaders {
 		select {
 		case <-ctx.Done():
@@ -44,37 +60,103 @@ func LoadWithLoadersWithContext(ctx context.Context, appName string, configStruc
 		default:
 		}
 
-		err := configLoader.LoadWithContext(ctx, appName, configStruct)
-		if err == nil {
+		loaded := reflect.New(structType).Interface()
+
+		loaderName := fmt.Sprintf("%T", configLoader)
+		start := time.Now()
+
+		err := configLoader.LoadWithContext(ctx, appName, loaded)
+
+		latency := time.Since(start)
+		revision := statusRevision(configLoader)
+
+		if err == nil || errors.Is(err, loader.ErrConfigDump) {
+			if mergeErr := DefaultMergeStrategy.Merge(configStruct, loaded); mergeErr != nil {
+				DefaultStatus.RecordRun(loaderName, loader.OutcomeError, latency, revision, mergeErr)
+
+				return fmt.Errorf("%T: %w", configLoader, mergeErr)
+			}
+
+			DefaultStatus.RecordRun(loaderName, loader.OutcomeSuccess, latency, revision, nil)
+
+			if errors.Is(err, loader.ErrConfigDump) {
+				return dumpConfigAndExit(configStruct)
+			}
+
 			continue
 		}
 
 		if errors.Is(err, loader.ErrNoClient) {
 			log.Ctx(ctx).Warn().
-				Str("loader", fmt.Sprintf("%T", configLoader)).
+				Str("loader", loaderName).
 				Msgf("%v, skipping", err)
 
+			DefaultStatus.RecordRun(loaderName, loader.OutcomeSkipped, latency, revision, err)
+
 			continue
 		}
 
 		if internal.IsLocalNetworkError(err) {
 			log.Ctx(ctx).Warn().
-				Str("loader", fmt.Sprintf("%T", configLoader)).
+				Str("loader", loaderName).
 				Msg("local server is not available, skipping")
 
+			DefaultStatus.RecordRun(loaderName, loader.OutcomeSkipped, latency, revision, err)
+
 			continue
 		}
 
 		if errors.Is(err, loader.ErrNoConfFile) {
 			log.Ctx(ctx).Warn().
-				Str("loader", fmt.Sprintf("%T", configLoader)).
+				Str("loader", loaderName).
 				Msgf("%v, skipping", err)
 
+			DefaultStatus.RecordRun(loaderName, loader.OutcomeSkipped, latency, revision, err)
+
 			continue
 		}
 
+		DefaultStatus.RecordRun(loaderName, loader.OutcomeError, latency, revision, err)
+
 		return fmt.Errorf("%T: %w", configLoader, err)
 	}
 
 	return nil
 }
+
+// statusRevision returns configLoader's last-loaded revision if it implements
+// loader.StatusReporter, or "" otherwise.
+func statusRevision(configLoader loader.Loader) string {
+	reporter, ok := configLoader.(loader.StatusReporter)
+	if !ok {
+		return ""
+	}
+
+	return reporter.LastRevision()
+}
+
+// dumpConfigAndExit prints configStruct (the fully-merged config, after every loader
+// including flags) as YAML to stdout, then exits the process. It is triggered by
+// loader.ErrConfigDump, returned by Flags.LoadWithContext when --config-dump was given.
+func dumpConfigAndExit(configStruct interface{}) error {
+	data, err := marshalConfigDump(configStruct)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(os.Stdout, string(data))
+	os.Exit(0)
+
+	return nil
+}
+
+// marshalConfigDump renders configStruct as YAML for --config-dump. Split out from
+// dumpConfigAndExit so the rendering itself is testable without exiting the process.
+func marshalConfigDump(configStruct interface{}) ([]byte, error) {
+	data, err := yaml.Marshal(configStruct)
+	if err != nil {
+		return nil, fmt.Errorf("dump config: %w", err)
+	}
+
+	return data, nil
+}
@@ -0,0 +1,69 @@
+package igconfig
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+
+	"gitlab.test.igdcs.com/finops/nextgen/utils/basics/igconfig.git/v2/loader"
+)
+
+// fakeLoader applies an arbitrary mutation to 'to', the same shape every built-in
+// loader has, without needing a real Consul/Vault/file backend.
+type fakeLoader struct {
+	apply func(to interface{})
+}
+
+func (f fakeLoader) LoadWithContext(_ context.Context, _ string, to interface{}) error {
+	f.apply(to)
+	return nil
+}
+
+func (f fakeLoader) Load(appName string, to interface{}) error {
+	return f.LoadWithContext(context.Background(), appName, to)
+}
+
+func TestLoadWithLoadersWithContext_MergesAcrossLoaders(t *testing.T) {
+	type cfg struct {
+		Name string
+		Port int
+	}
+
+	first := fakeLoader{apply: func(to interface{}) {
+		c := to.(*cfg)
+		c.Name = "from-first-loader"
+		c.Port = 8080
+	}}
+	second := fakeLoader{apply: func(to interface{}) {
+		c := to.(*cfg)
+		c.Port = 9090 // only overrides Port, Name should survive from 'first'
+	}}
+
+	var got cfg
+
+	err := LoadWithLoadersWithContext(context.Background(), "app", &got, first, second)
+
+	require.NoError(t, err)
+	assert.Equal(t, cfg{Name: "from-first-loader", Port: 9090}, got)
+}
+
+func TestMarshalConfigDump_ReflectsMergedConfig(t *testing.T) {
+	type cfg struct {
+		Name string
+		Port int
+	}
+
+	got := cfg{Name: "merged", Port: 9090}
+
+	data, err := marshalConfigDump(&got)
+	require.NoError(t, err)
+
+	var roundTripped cfg
+	require.NoError(t, yaml.Unmarshal(data, &roundTripped))
+	assert.Equal(t, got, roundTripped)
+}
+
+var _ loader.Loader = fakeLoader{}
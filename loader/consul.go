@@ -3,10 +3,15 @@ package loader
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"net"
 	"os"
 	"path"
+	"reflect"
+	"strconv"
+	"strings"
 
 	"gitlab.test.igdcs.com/finops/nextgen/utils/basics/igconfig.git/v2/codec"
 
@@ -18,14 +23,32 @@ import (
 
 var ConsulTag = "cfg"
 
+// ConsulServiceTag is the struct tag used to mark fields that should be resolved
+// to live Consul service endpoints instead of plain config values.
+//
+// Format: `cfgsvc:"service-name,tag=foo"`. Multiple tag filters can be given as
+// repeated "tag=" parts, e.g. `cfgsvc:"service-name,tag=foo,tag=bar"`.
+var ConsulServiceTag = "cfgsvc"
+
+// ServiceEndpoint is a resolved Consul service instance address.
+//
+// Fields of this type tagged with ConsulServiceTag will be populated with one
+// of the healthy instances found for the requested service.
+type ServiceEndpoint struct {
+	Address string
+	Port    int
+}
+
 // ConsulConfigPathPrefix specifies prefix for key search.
 var ConsulConfigPathPrefix = "finops"
 
 var ErrNoClient = errors.New("no client available")
 
-var _ Loader = Consul{}
+var _ Loader = &Consul{}
+
+var _ DynamicValuer = &Consul{}
 
-var _ DynamicValuer = Consul{}
+var _ StatusReporter = &Consul{}
 
 // LiveServiceFetcher is a signature of the function that will fetch only live instances of the service.
 //
@@ -59,34 +82,231 @@ type Consul struct {
 	//
 	// Note: this function is not used in Watcher.
 	Decoder codec.Decoder
+
+	// lastRevision is the LastIndex of the KV query last successfully loaded, set by
+	// LoadWithContext and reported via LastRevision.
+	lastRevision string
 }
 
 // LoadWithContext retrieves data from Consul and decode response into 'to' struct.
-func (c Consul) LoadWithContext(ctx context.Context, appName string, to interface{}) error {
+func (c *Consul) LoadWithContext(ctx context.Context, appName string, to interface{}) error {
 	if err := c.EnsureClient(); err != nil {
 		return err
 	}
 
 	queryOptions := api.QueryOptions{}
-	data, _, err := c.Client.KV().Get(getConsulConfigPath(appName), queryOptions.WithContext(ctx))
-	// If no data or err is returned - return early.
-	if data == nil || err != nil {
+	data, meta, err := c.Client.KV().Get(getConsulConfigPath(appName), queryOptions.WithContext(ctx))
+	if err != nil {
 		return err
 	}
 
-	if c.Decoder == nil {
-		c.Decoder = codec.YAML{}
+	// A missing KV blob is not an error: an app may rely on cfgsvc tags for service
+	// discovery alone, without publishing a config blob at all.
+	if data != nil {
+		if c.Decoder == nil {
+			c.Decoder = codec.YAML{}
+		}
+
+		if err := codec.LoadReaderWithDecoder(bytes.NewReader(data.Value), to, c.Decoder, ConsulTag); err != nil {
+			return fmt.Errorf("Consul.LoadWithContext error: %w", err)
+		}
+
+		c.lastRevision = strconv.FormatUint(meta.LastIndex, 10)
+	}
+
+	if err := c.loadServiceFields(ctx, to); err != nil {
+		return fmt.Errorf("Consul.LoadWithContext service fields error: %w", err)
+	}
+
+	return nil
+}
+
+// LastRevision returns the LastIndex of the KV query last successfully loaded, or ""
+// if LoadWithContext has not yet succeeded.
+func (c *Consul) LastRevision() string {
+	return c.lastRevision
+}
+
+// loadServiceFields walks 'to' for fields tagged with ConsulServiceTag and populates
+// them with healthy instances of the named service.
+//
+// Supported field types are string, []string and ServiceEndpoint.
+func (c *Consul) loadServiceFields(ctx context.Context, to interface{}) error {
+	v := reflect.ValueOf(to)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return nil
+	}
+
+	v = v.Elem()
+	t := v.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		tagVal, ok := t.Field(i).Tag.Lookup(ConsulServiceTag)
+		if !ok {
+			continue
+		}
+
+		name, tags := parseServiceTag(tagVal)
+
+		services, err := c.SearchLiveServices(ctx, name, tags)
+		if err != nil {
+			return err
+		}
+
+		if err := setServiceField(v.Field(i), services); err != nil {
+			return fmt.Errorf("field %q: %w", t.Field(i).Name, err)
+		}
+	}
+
+	return nil
+}
+
+// parseServiceTag splits a `cfgsvc:"service-name,tag=foo,tag=bar"` tag value into
+// the service name and the list of requested tags.
+func parseServiceTag(tagVal string) (name string, tags []string) {
+	parts := strings.Split(tagVal, ",")
+	name = parts[0]
+
+	for _, part := range parts[1:] {
+		if t := strings.TrimPrefix(part, "tag="); t != part {
+			tags = append(tags, t)
+		}
+	}
+
+	return name, tags
+}
+
+// setServiceField populates a single cfgsvc-tagged field from resolved service entries.
+func setServiceField(field reflect.Value, services []*api.ServiceEntry) error {
+	addrs := make([]string, 0, len(services))
+	for _, svc := range services {
+		addrs = append(addrs, serviceHostPort(svc))
 	}
 
-	if err := codec.LoadReaderWithDecoder(bytes.NewReader(data.Value), to, c.Decoder, ConsulTag); err != nil {
-		return fmt.Errorf("Consul.LoadWithContext error: %w", err)
+	switch field.Interface().(type) {
+	case string:
+		if len(addrs) > 0 {
+			field.SetString(addrs[0])
+		}
+	case []string:
+		field.Set(reflect.ValueOf(addrs))
+	case ServiceEndpoint:
+		if len(services) == 0 {
+			return nil
+		}
+
+		field.Set(reflect.ValueOf(ServiceEndpoint{
+			Address: serviceAddress(services[0]),
+			Port:    services[0].Service.Port,
+		}))
+	default:
+		return fmt.Errorf("unsupported cfgsvc field type %s", field.Type())
 	}
 
 	return nil
 }
 
+var _ DynamicValuer = &ConsulService{}
+
+// ConsulService adapts ServiceDynamicValue to the DynamicValuer interface by binding the
+// service name and tags ServiceDynamicValue otherwise takes as extra arguments.
+//
+// WARNING: this is experimental feature and is not guaranteed to work. Also it could be changed at will.
+type ConsulService struct {
+	Consul *Consul
+	// Name is the Consul service name to watch.
+	Name string
+	// Tags, if given, restrict the watch to instances carrying all of them.
+	Tags []string
+}
+
+// DynamicValue implements DynamicValuer by delegating to Consul.ServiceDynamicValue.
+func (s ConsulService) DynamicValue(ctx context.Context, config DynamicConfig) error {
+	consul := s.Consul
+	if consul == nil {
+		consul = &Consul{}
+	}
+
+	return consul.ServiceDynamicValue(ctx, config, s.Name, s.Tags)
+}
+
+// ServiceDynamicValue is a DynamicValuer-style watcher for service-discovery fields.
+//
+// WARNING: this is experimental feature and is not guaranteed to work. Also it could be changed at will.
+//
+// ---
+//
+// Unlike DynamicValue (which watches a single KV key), ServiceDynamicValue uses a blocking
+// health query (analogous to watch.Parse's key watch, but for Health().ServiceMultipleTags)
+// so config.Runner is called with the up-to-date, JSON-encoded list of "host:port" endpoints
+// whenever instances of the named service come up, go down, or fail health checks.
+//
+// Most callers should use ConsulService instead, which implements DynamicValuer directly.
+func (c *Consul) ServiceDynamicValue(ctx context.Context, config DynamicConfig, serviceName string, tags []string) error {
+	if err := c.EnsureClient(); err != nil {
+		return err
+	}
+
+	runner := config.Runner
+	if config.Status != nil {
+		runner = config.Status.WrapDynamicRunner(fmt.Sprintf("%T", c), config.FieldName, runner)
+		// The watch is only reported up while this function is running; mark it down
+		// however it ends, not just when the runner itself errors.
+		defer config.Status.RecordDynamicUpdate(fmt.Sprintf("%T", c), config.FieldName, false)
+	}
+
+	var lastIndex uint64
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		queryOptions := (&api.QueryOptions{WaitIndex: lastIndex}).WithContext(ctx)
+
+		services, meta, err := c.Client.Health().ServiceMultipleTags(serviceName, tags, true, queryOptions)
+		if err != nil {
+			return fmt.Errorf("fetch service instances: %w", err)
+		}
+
+		if meta.LastIndex == lastIndex {
+			continue
+		}
+
+		lastIndex = meta.LastIndex
+
+		addrs := make([]string, 0, len(services))
+		for _, svc := range services {
+			addrs = append(addrs, serviceHostPort(svc))
+		}
+
+		data, err := json.Marshal(addrs)
+		if err != nil {
+			return fmt.Errorf("marshal service addresses: %w", err)
+		}
+
+		if err := executeRunner(ctx, config.FieldName, data, runner); err != nil {
+			return err
+		}
+	}
+}
+
+func serviceAddress(svc *api.ServiceEntry) string {
+	if svc.Service.Address != "" {
+		return svc.Service.Address
+	}
+
+	return svc.Node.Address
+}
+
+func serviceHostPort(svc *api.ServiceEntry) string {
+	return net.JoinHostPort(serviceAddress(svc), strconv.Itoa(svc.Service.Port))
+}
+
 // Load is just same as LoadWithContext without context.
-func (c Consul) Load(appName string, to interface{}) error {
+func (c *Consul) Load(appName string, to interface{}) error {
 	return c.LoadWithContext(context.Background(), appName, to)
 }
 
@@ -138,7 +358,7 @@ func (c Consul) Load(appName string, to interface{}) error {
 //		}
 //	}()
 //
-func (c Consul) DynamicValue(ctx context.Context, config DynamicConfig) error {
+func (c *Consul) DynamicValue(ctx context.Context, config DynamicConfig) error {
 	if err := c.EnsureClient(); err != nil {
 		return err
 	}
@@ -157,6 +377,14 @@ func (c Consul) DynamicValue(ctx context.Context, config DynamicConfig) error {
 		plan.Stop()
 	}()
 
+	runner := config.Runner
+	if config.Status != nil {
+		runner = config.Status.WrapDynamicRunner(fmt.Sprintf("%T", c), config.FieldName, runner)
+		// The watch is only reported up while this function is running; mark it down
+		// however it ends, not just when the runner itself errors.
+		defer config.Status.RecordDynamicUpdate(fmt.Sprintf("%T", c), config.FieldName, false)
+	}
+
 	var handlerErr error
 
 	plan.HybridHandler = func(_ watch.BlockingParamVal, raw interface{}) {
@@ -176,7 +404,7 @@ func (c Consul) DynamicValue(ctx context.Context, config DynamicConfig) error {
 			}
 		}
 
-		if execErr := executeRunner(watchCtx, config.FieldName, data, config.Runner); execErr != nil {
+		if execErr := executeRunner(watchCtx, config.FieldName, data, runner); execErr != nil {
 			handlerErr = execErr
 
 			stopWatcher()
@@ -94,7 +94,8 @@ snake_case_struct:
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			err := Consul{Client: NewConsulMock(test.consulConf)}.Load(test.name, &test.to)
+			consul := Consul{Client: NewConsulMock(test.consulConf)}
+			err := consul.Load(test.name, &test.to)
 
 			if test.err == "" {
 				assert.NoError(t, err)
@@ -108,6 +109,30 @@ snake_case_struct:
 	}
 }
 
+func TestLoadFromConsul_NoKVWithServiceField(t *testing.T) {
+	type res struct {
+		Endpoint ServiceEndpoint `cfgsvc:"payments"`
+	}
+
+	services := map[string][]*api.ServiceEntry{
+		"payments": {
+			{
+				Node:    &api.Node{Address: "10.0.0.1"},
+				Service: &api.AgentService{Address: "10.0.0.2", Port: 8080},
+			},
+		},
+	}
+
+	consul := Consul{Client: NewConsulMock(ConsulMock{kv: map[string][]byte{}, services: services})}
+
+	var to res
+
+	err := consul.Load("app-with-no-kv-blob", &to)
+	require.NoError(t, err)
+
+	assert.Equal(t, ServiceEndpoint{Address: "10.0.0.2", Port: 8080}, to.Endpoint)
+}
+
 func TestNewConsuler_WrongAddr(t *testing.T) {
 	c, err := NewConsul("locall:8787")
 
@@ -159,6 +184,104 @@ func TestConsul_DynamicValue(t *testing.T) {
 	assert.Equal(t, context.DeadlineExceeded, err)
 }
 
+func TestParseServiceTag(t *testing.T) {
+	tests := []struct {
+		tagVal string
+		name   string
+		tags   []string
+	}{
+		{tagVal: "payments", name: "payments"},
+		{tagVal: "payments,tag=primary", name: "payments", tags: []string{"primary"}},
+		{tagVal: "payments,tag=primary,tag=eu", name: "payments", tags: []string{"primary", "eu"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.tagVal, func(t *testing.T) {
+			name, tags := parseServiceTag(test.tagVal)
+
+			assert.Equal(t, test.name, name)
+			assert.Equal(t, test.tags, tags)
+		})
+	}
+}
+
+func TestConsul_loadServiceFields(t *testing.T) {
+	type res struct {
+		Addr      string          `cfgsvc:"payments"`
+		Addrs     []string        `cfgsvc:"payments"`
+		Endpoint  ServiceEndpoint `cfgsvc:"payments,tag=primary"`
+		Untouched string
+	}
+
+	services := map[string][]*api.ServiceEntry{
+		"payments": {
+			{
+				Node:    &api.Node{Address: "10.0.0.1"},
+				Service: &api.AgentService{Address: "10.0.0.2", Port: 8080},
+			},
+		},
+	}
+
+	consul := Consul{Client: NewConsulMock(ConsulMock{services: services})}
+
+	var to res
+
+	err := consul.loadServiceFields(context.Background(), &to)
+	require.NoError(t, err)
+
+	assert.Equal(t, "10.0.0.2:8080", to.Addr)
+	assert.Equal(t, []string{"10.0.0.2:8080"}, to.Addrs)
+	assert.Equal(t, ServiceEndpoint{Address: "10.0.0.2", Port: 8080}, to.Endpoint)
+	assert.Empty(t, to.Untouched)
+}
+
+func TestConsul_loadServiceFields_NoInstances(t *testing.T) {
+	type res struct {
+		Endpoint ServiceEndpoint `cfgsvc:"payments"`
+	}
+
+	consul := Consul{Client: NewConsulMock(ConsulMock{services: map[string][]*api.ServiceEntry{}})}
+
+	var to res
+
+	err := consul.loadServiceFields(context.Background(), &to)
+
+	require.NoError(t, err)
+	assert.Equal(t, ServiceEndpoint{}, to.Endpoint)
+}
+
+func TestConsulService_DynamicValue(t *testing.T) {
+	services := map[string][]*api.ServiceEntry{
+		"payments": {
+			{
+				Node:    &api.Node{Address: "10.0.0.1"},
+				Service: &api.AgentService{Address: "10.0.0.2", Port: 8080},
+			},
+		},
+	}
+
+	consul := &Consul{Client: NewConsulMock(ConsulMock{services: services})}
+	svc := ConsulService{Consul: consul, Name: "payments"}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var got []string
+
+	err := svc.DynamicValue(ctx, DynamicConfig{
+		AppName:   "app",
+		FieldName: "payments",
+		Runner: func(value []byte) error {
+			require.NoError(t, json.Unmarshal(value, &got))
+			cancel()
+
+			return nil
+		},
+	})
+
+	assert.Equal(t, []string{"10.0.0.2:8080"}, got)
+	assert.Equal(t, context.Canceled, err)
+}
+
 func NewConsulMock(mockConfig ConsulMock) *api.Client {
 	cl, _ := api.NewClient(&api.Config{
 		HttpClient: &http.Client{
@@ -173,6 +296,9 @@ type ConsulMock struct {
 	kvFunc func(keyPath string) (*api.KVPair, *api.QueryMeta, bool)
 	kv     map[string][]byte
 	err    error
+	// services, keyed by service name, backs the /v1/health/service/ endpoint used by
+	// SearchLiveServices, loadServiceFields and ServiceDynamicValue.
+	services map[string][]*api.ServiceEntry
 }
 
 func (m ConsulMock) RoundTrip(request *http.Request) (*http.Response, error) {
@@ -198,6 +324,18 @@ func (m ConsulMock) RoundTrip(request *http.Request) (*http.Response, error) {
 		httpResp.Header = generateMetaHeader(meta)
 
 		return &httpResp, err
+	case strings.HasPrefix(reqURI, "/v1/health/service/"):
+		name := strings.TrimPrefix(strings.SplitN(reqURI, "?", 2)[0], "/v1/health/service/")
+
+		entries := m.services[name]
+
+		bts, _ := json.Marshal(entries)
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(bytes.NewReader(bts)),
+			Header:     generateMetaHeader(&api.QueryMeta{LastIndex: uint64(len(entries))}),
+		}, nil
 	}
 
 	return nil, fmt.Errorf("%s %s", request.Method, request.URL.RequestURI())
@@ -0,0 +1,97 @@
+package loader
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"time"
+
+	"gitlab.test.igdcs.com/finops/nextgen/utils/basics/igconfig.git/v2/codec"
+)
+
+// ErrNoConfFile means that no config file was found for the requested application.
+var ErrNoConfFile = errors.New("no config file found")
+
+var _ Loader = &File{}
+
+var _ StatusReporter = &File{}
+
+// File is an instance of configuration loader from a local file.
+//
+// Example usage:
+//
+//	var config Config // some Config struct
+//
+//	fileLoader := File{}
+//	err := fileLoader.Load("adm0001s", &config)
+//	if err != nil { ... }
+//
+//	// config is now populated from the file pointed to by ADM0001S_CONFIG_FILE.
+type File struct {
+	// Path overrides the config file location.
+	//
+	// If empty, it is taken from the "<APPNAME>_CONFIG_FILE" environment variable.
+	Path string
+	// Decoder specifies function that will decode the contents of the file.
+	// By default it is YAML parser.
+	Decoder codec.Decoder
+
+	// lastRevision is the mtime of the file last successfully loaded, set by
+	// LoadWithContext and reported via LastRevision.
+	lastRevision string
+}
+
+// LoadWithContext reads the config file and decodes its contents into 'to' struct.
+func (f *File) LoadWithContext(_ context.Context, appName string, to interface{}) error {
+	path := f.Path
+	if path == "" {
+		path = os.Getenv(strings.ToUpper(appName) + "_CONFIG_FILE")
+	}
+
+	if path == "" {
+		return ErrNoConfFile
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return ErrNoConfFile
+		}
+
+		return err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return ErrNoConfFile
+		}
+
+		return err
+	}
+
+	if f.Decoder == nil {
+		f.Decoder = codec.YAML{}
+	}
+
+	if err := codec.LoadReaderWithDecoder(bytes.NewReader(data), to, f.Decoder, ConsulTag); err != nil {
+		return err
+	}
+
+	f.lastRevision = info.ModTime().UTC().Format(time.RFC3339Nano)
+
+	return nil
+}
+
+// LastRevision returns the mtime of the file last successfully loaded, formatted as
+// RFC3339Nano, or "" if LoadWithContext has not yet succeeded.
+func (f *File) LastRevision() string {
+	return f.lastRevision
+}
+
+// Load is just same as LoadWithContext without context.
+func (f *File) Load(appName string, to interface{}) error {
+	return f.LoadWithContext(context.Background(), appName, to)
+}
@@ -0,0 +1,50 @@
+package loader
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFile_LoadWithContext(t *testing.T) {
+	type res struct {
+		UntaggedStr string
+	}
+
+	dir := t.TempDir()
+	confPath := filepath.Join(dir, "config.yaml")
+
+	require.NoError(t, os.WriteFile(confPath, []byte("untaggedStr: from file\n"), 0o600))
+
+	file := File{Path: confPath}
+
+	var to res
+
+	require.NoError(t, file.Load("app", &to))
+	assert.Equal(t, res{UntaggedStr: "from file"}, to)
+	assert.NotEmpty(t, file.LastRevision())
+}
+
+func TestFile_LoadWithContext_NoFile(t *testing.T) {
+	file := File{Path: filepath.Join(t.TempDir(), "missing.yaml")}
+
+	var to struct{}
+
+	err := file.Load("app", &to)
+
+	assert.ErrorIs(t, err, ErrNoConfFile)
+	assert.Empty(t, file.LastRevision())
+}
+
+func TestFile_LoadWithContext_NoPathConfigured(t *testing.T) {
+	file := File{}
+
+	var to struct{}
+
+	err := file.Load("unconfigured-app", &to)
+
+	assert.ErrorIs(t, err, ErrNoConfFile)
+}
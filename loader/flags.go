@@ -0,0 +1,262 @@
+package loader
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// FlagTag is the struct tag used to describe a command-line flag for a field.
+//
+// Format: `flag:"name,shortname,usage"`. Both shortname and usage are optional,
+// e.g. `flag:"loglevel,l,sets the minimum log level"` or just `flag:"loglevel"`.
+var FlagTag = "flag"
+
+// ErrConfigDump is returned by Flags.LoadWithContext when --config-dump was given.
+//
+// Flags only has its own, per-loader view of the config struct (see
+// LoadWithLoadersWithContext), so it cannot dump the fully-merged result itself: it
+// signals the request via this error and lets the caller dump and exit once the merge
+// is done.
+var ErrConfigDump = errors.New("config dump requested")
+
+var _ Loader = &Flags{}
+
+// Flags is a command-line loader: it walks the target struct via reflection, registers
+// a real flag.FlagSet with one flag per FlagTag-annotated field (fields of nested structs
+// whose tag gives no name get dotted names, e.g. "DB.Host"), and additionally registers:
+//
+//   - "--help", auto-generated from every configurable field, its type, default value,
+//     and environment-variable equivalent;
+//   - "--config-dump", which prints the fully-merged config (after all loaders, including
+//     any flags given on the same command line) as YAML, then exits.
+//
+// Example usage:
+//
+//	var config Config // some Config struct
+//
+//	flagsLoader := Flags{}
+//	err := flagsLoader.Load("adm0001s", &config)
+//	if err != nil { ... }
+type Flags struct {
+	// Args overrides the arguments parsed, os.Args[1:] is used if nil.
+	Args []string
+	// Output is where --help writes to. Defaults to os.Stderr, matching the standard
+	// library flag package's convention for --help. --config-dump does not use it: the
+	// dump is written to os.Stdout by the caller that handles ErrConfigDump.
+	Output io.Writer
+}
+
+// flagField describes a single registered flag, used to build --help output.
+type flagField struct {
+	Name    string
+	EnvName string
+	Type    string
+	Default string
+	Usage   string
+}
+
+// LoadWithContext registers one flag per tagged field of 'to' and parses them.
+func (f *Flags) LoadWithContext(_ context.Context, appName string, to interface{}) error {
+	v := reflect.ValueOf(to)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("Flags.LoadWithContext: 'to' must be a pointer to struct, got %T", to)
+	}
+
+	fs := flag.NewFlagSet(appName, flag.ExitOnError)
+
+	out := f.Output
+	if out == nil {
+		out = os.Stderr
+	}
+
+	fs.SetOutput(out)
+
+	var fields []flagField
+
+	if err := registerFlags(fs, v.Elem(), "", &fields); err != nil {
+		return fmt.Errorf("Flags.LoadWithContext: %w", err)
+	}
+
+	dump := fs.Bool("config-dump", false, "print the fully-merged config as YAML and exit")
+
+	fs.Usage = func() { printFlagsHelp(out, appName, fields) }
+
+	args := f.Args
+	if args == nil {
+		args = os.Args[1:]
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("Flags.LoadWithContext: parse flags: %w", err)
+	}
+
+	if *dump {
+		return ErrConfigDump
+	}
+
+	return nil
+}
+
+// Load is just same as LoadWithContext without context.
+func (f *Flags) Load(appName string, to interface{}) error {
+	return f.LoadWithContext(context.Background(), appName, to)
+}
+
+// registerFlags walks struct 'v' registering a flag for every FlagTag-annotated field.
+// Nested structs are walked recursively. A field whose tag gives no name is named
+// "<parent>.<Field>"; a name given in the tag is used as-is, without the prefix.
+func registerFlags(fs *flag.FlagSet, v reflect.Value, prefix string, fields *[]flagField) error {
+	t := v.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		fieldVal := v.Field(i)
+
+		if fieldVal.Kind() == reflect.Struct && fieldVal.Type() != reflect.TypeOf(time.Duration(0)) {
+			nestedPrefix := prefix + field.Name + "."
+
+			if err := registerFlags(fs, fieldVal, nestedPrefix, fields); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		tagVal, ok := field.Tag.Lookup(FlagTag)
+		if !ok {
+			continue
+		}
+
+		name, shortName, usage := parseFlagTag(tagVal, prefix+field.Name)
+
+		envName := field.Tag.Get("env")
+		if envName == "" {
+			envName = strings.ToUpper(field.Name)
+		}
+
+		if err := registerFlagVar(fs, fieldVal, name, usage); err != nil {
+			return fmt.Errorf("field %q: %w", field.Name, err)
+		}
+
+		if shortName != "" {
+			if err := registerFlagVar(fs, fieldVal, shortName, usage+" (shorthand for --"+name+")"); err != nil {
+				return fmt.Errorf("field %q: %w", field.Name, err)
+			}
+		}
+
+		*fields = append(*fields, flagField{
+			Name:    name,
+			EnvName: envName,
+			Type:    fieldVal.Type().String(),
+			Default: fmt.Sprintf("%v", fieldVal.Interface()),
+			Usage:   usage,
+		})
+	}
+
+	return nil
+}
+
+// parseFlagTag splits a `flag:"name,shortname,usage"` tag value, falling back to
+// fallbackName when no name is given.
+func parseFlagTag(tagVal, fallbackName string) (name, shortName, usage string) {
+	parts := strings.SplitN(tagVal, ",", 3)
+
+	name = parts[0]
+	if name == "" {
+		name = fallbackName
+	}
+
+	if len(parts) > 1 {
+		shortName = parts[1]
+	}
+
+	if len(parts) > 2 {
+		usage = parts[2]
+	}
+
+	return name, shortName, usage
+}
+
+// registerFlagVar registers fieldVal's address against name on fs, dispatching on the
+// field's type. Slice fields accept either comma-separated values in one flag, or
+// repeated uses of the flag.
+func registerFlagVar(fs *flag.FlagSet, fieldVal reflect.Value, name, usage string) error {
+	switch ptr := fieldVal.Addr().Interface().(type) {
+	case *string:
+		fs.StringVar(ptr, name, *ptr, usage)
+	case *bool:
+		fs.BoolVar(ptr, name, *ptr, usage)
+	case *int:
+		fs.IntVar(ptr, name, *ptr, usage)
+	case *int64:
+		fs.Int64Var(ptr, name, *ptr, usage)
+	case *float64:
+		fs.Float64Var(ptr, name, *ptr, usage)
+	case *time.Duration:
+		fs.DurationVar(ptr, name, *ptr, usage)
+	case *[]string:
+		fs.Var(newSliceFlag(ptr), name, usage)
+	default:
+		return fmt.Errorf("unsupported flag field type %s", fieldVal.Type())
+	}
+
+	return nil
+}
+
+// sliceFlag implements flag.Value for []string fields, supporting both a single
+// comma-separated value and repeated uses of the same flag.
+type sliceFlag struct {
+	target *[]string
+	set    bool
+}
+
+func newSliceFlag(target *[]string) *sliceFlag {
+	return &sliceFlag{target: target}
+}
+
+func (s *sliceFlag) String() string {
+	if s.target == nil {
+		return ""
+	}
+
+	return strings.Join(*s.target, ",")
+}
+
+func (s *sliceFlag) Set(value string) error {
+	if !s.set {
+		*s.target = nil
+		s.set = true
+	}
+
+	*s.target = append(*s.target, strings.Split(value, ",")...)
+
+	return nil
+}
+
+// printFlagsHelp writes the auto-generated --help output: every configurable field,
+// its type, default value, source precedence, and environment-variable equivalent.
+func printFlagsHelp(out io.Writer, appName string, fields []flagField) {
+	fmt.Fprintf(out, "Usage of %s:\n", appName)
+	fmt.Fprintln(out, "Precedence (highest to lowest): flags > environment > file > Vault > Consul > defaults.")
+	fmt.Fprintln(out)
+
+	for _, field := range fields {
+		fmt.Fprintf(out, "  --%s %s\n", field.Name, field.Type)
+
+		if field.Usage != "" {
+			fmt.Fprintf(out, "\t%s\n", field.Usage)
+		}
+
+		fmt.Fprintf(out, "\tdefault: %s, env: %s\n", field.Default, field.EnvName)
+	}
+
+	fmt.Fprintln(out, "  --config-dump bool")
+	fmt.Fprintln(out, "\tprint the fully-merged config as YAML and exit")
+}
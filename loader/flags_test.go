@@ -0,0 +1,64 @@
+package loader
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlags_LoadWithContext(t *testing.T) {
+	type inner struct {
+		Host string `flag:"db.host"`
+	}
+	type cfg struct {
+		Name  string   `flag:"name,n,application name"`
+		Port  int      `flag:"port"`
+		Debug bool     `flag:"debug"`
+		Tags  []string `flag:"tags"`
+		DB    inner
+	}
+
+	flags := Flags{Args: []string{"--name", "svc", "-n", "shorthand", "--port", "9090", "--debug", "--tags", "a,b", "--db.host", "localhost"}}
+
+	var to cfg
+
+	err := flags.Load("app", &to)
+
+	require.NoError(t, err)
+	assert.Equal(t, cfg{
+		Name:  "shorthand", // the shorthand flag is parsed after --name and wins
+		Port:  9090,
+		Debug: true,
+		Tags:  []string{"a", "b"},
+		DB:    inner{Host: "localhost"},
+	}, to)
+}
+
+func TestFlags_LoadWithContext_ConfigDump(t *testing.T) {
+	type cfg struct {
+		Name string `flag:"name"`
+	}
+
+	flags := Flags{Args: []string{"--config-dump"}}
+
+	var to cfg
+
+	err := flags.Load("app", &to)
+
+	assert.True(t, errors.Is(err, ErrConfigDump))
+}
+
+func TestPrintFlagsHelp(t *testing.T) {
+	var out bytes.Buffer
+
+	printFlagsHelp(&out, "app", []flagField{
+		{Name: "name", EnvName: "NAME", Type: "string", Default: "", Usage: "application name"},
+	})
+
+	assert.Contains(t, out.String(), "--name")
+	assert.Contains(t, out.String(), "application name")
+	assert.Contains(t, out.String(), "--config-dump")
+}
@@ -20,6 +20,11 @@ type DynamicConfig struct {
 	//
 	// Returning error will stop dynamic updates, so it should be restarted manually.
 	Runner DynamicRunner
+	// Status, if set, is used to record the health of this watch (via
+	// Status.RecordDynamicUpdate, wrapping Runner) so it shows up in Status.Snapshot and
+	// the igconfig_dynamic_watch_up metric: up while DynamicValue is running, down once
+	// it returns for any reason. Left nil, a watch is not tracked at all.
+	Status *Status
 }
 
 type Loader interface {
@@ -28,6 +33,12 @@ type Loader interface {
 	// Even if particular loader type must implement ReflectLoader -
 	// this interface still must be implemented as a proxy.
 	Load(appName string, to interface{}) error
+
+	// LoadWithContext is just like Load, but honors ctx cancellation.
+	//
+	// Every built-in loader implements this directly; Load is usually just
+	// LoadWithContext(context.Background(), ...).
+	LoadWithContext(ctx context.Context, appName string, to interface{}) error
 }
 
 type ReflectLoader interface {
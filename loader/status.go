@@ -0,0 +1,176 @@
+package loader
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// RunOutcome is the result of a single loader run, as recorded by Status.
+type RunOutcome string
+
+const (
+	OutcomeSuccess RunOutcome = "success"
+	OutcomeSkipped RunOutcome = "skipped"
+	OutcomeError   RunOutcome = "error"
+)
+
+// StatusReporter is an optional interface a Loader can implement to expose the revision
+// of the data it last loaded, e.g. a Consul LastIndex, a Vault lease ID, or a file's mtime.
+//
+// Loaders that don't implement it simply have an empty Revision reported.
+type StatusReporter interface {
+	// LastRevision returns an opaque, loader-specific token identifying the data last
+	// successfully loaded. Empty string means no revision is available.
+	LastRevision() string
+}
+
+// RunStatus is a snapshot of the outcome of one loader run.
+type RunStatus struct {
+	Loader    string        `json:"loader"`
+	Outcome   RunOutcome    `json:"outcome"`
+	Latency   time.Duration `json:"latency"`
+	Revision  string        `json:"revision,omitempty"`
+	Error     string        `json:"error,omitempty"`
+	Timestamp time.Time     `json:"timestamp"`
+}
+
+// DynamicStatus is a snapshot of the health of one dynamic (DynamicValuer) watch.
+type DynamicStatus struct {
+	Loader         string    `json:"loader"`
+	FieldName      string    `json:"fieldName"`
+	Up             bool      `json:"up"`
+	LastUpdateTime time.Time `json:"lastUpdateTime"`
+}
+
+// Status records the outcome of every configured loader run, plus the health of any
+// dynamic watches, so operators get the same "is my config source healthy?" visibility
+// Nomad/Consul provide for their own subsystems.
+//
+// A Status is safe for concurrent use.
+type Status struct {
+	mu      sync.RWMutex
+	runs    map[string]RunStatus
+	dynamic map[string]DynamicStatus
+}
+
+// NewStatus creates an empty Status.
+func NewStatus() *Status {
+	return &Status{
+		runs:    make(map[string]RunStatus),
+		dynamic: make(map[string]DynamicStatus),
+	}
+}
+
+// RecordRun records the outcome of a single loader run and updates the associated
+// Prometheus metrics.
+func (s *Status) RecordRun(loaderName string, outcome RunOutcome, latency time.Duration, revision string, err error) {
+	run := RunStatus{
+		Loader:    loaderName,
+		Outcome:   outcome,
+		Latency:   latency,
+		Revision:  revision,
+		Timestamp: time.Now(),
+	}
+
+	if err != nil {
+		run.Error = err.Error()
+	}
+
+	s.mu.Lock()
+	s.runs[loaderName] = run
+	s.mu.Unlock()
+
+	loadsTotal.WithLabelValues(loaderName, string(outcome)).Inc()
+
+	if outcome == OutcomeSuccess {
+		lastSuccessTimestamp.WithLabelValues(loaderName).Set(float64(run.Timestamp.Unix()))
+	}
+}
+
+// RecordDynamicUpdate records the health of a dynamic (DynamicValuer) watch and updates
+// the associated Prometheus metric. Call it from inside a DynamicRunner, or wrap the
+// runner with WrapDynamicRunner to do so automatically.
+func (s *Status) RecordDynamicUpdate(loaderName, fieldName string, up bool) {
+	key := loaderName + "/" + fieldName
+
+	status := DynamicStatus{
+		Loader:    loaderName,
+		FieldName: fieldName,
+		Up:        up,
+	}
+
+	if up {
+		status.LastUpdateTime = time.Now()
+	}
+
+	s.mu.Lock()
+	if up {
+		s.dynamic[key] = status
+	} else if prev, ok := s.dynamic[key]; ok {
+		prev.Up = false
+		s.dynamic[key] = prev
+	} else {
+		s.dynamic[key] = status
+	}
+	s.mu.Unlock()
+
+	upVal := 0.0
+	if up {
+		upVal = 1.0
+	}
+
+	dynamicWatchUp.WithLabelValues(loaderName, fieldName).Set(upVal)
+}
+
+// WrapDynamicRunner wraps runner so that every invocation is recorded against
+// loaderName/fieldName in s, in addition to being passed through to runner.
+func (s *Status) WrapDynamicRunner(loaderName, fieldName string, runner DynamicRunner) DynamicRunner {
+	return func(value []byte) error {
+		err := runner(value)
+		s.RecordDynamicUpdate(loaderName, fieldName, err == nil)
+
+		return err
+	}
+}
+
+// Snapshot returns the current status of every loader run and dynamic watch recorded
+// so far, suitable for JSON serialization.
+func (s *Status) Snapshot() map[string]interface{} {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	runs := make([]RunStatus, 0, len(s.runs))
+	for _, run := range s.runs {
+		runs = append(runs, run)
+	}
+
+	dynamic := make([]DynamicStatus, 0, len(s.dynamic))
+	for _, d := range s.dynamic {
+		dynamic = append(dynamic, d)
+	}
+
+	return map[string]interface{}{
+		"runs":    runs,
+		"dynamic": dynamic,
+	}
+}
+
+var (
+	loadsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "igconfig_loader_loads_total",
+		Help: "Total number of loader runs, by loader and outcome (success, skipped, error).",
+	}, []string{"loader", "outcome"})
+
+	lastSuccessTimestamp = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "igconfig_loader_last_success_timestamp_seconds",
+		Help: "Unix timestamp of the last successful run of a loader.",
+	}, []string{"loader"})
+
+	dynamicWatchUp = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "igconfig_dynamic_watch_up",
+		Help: "Whether a dynamic (DynamicValuer) watch is currently up (1) or not (0), by loader and field.",
+	}, []string{"loader", "field"})
+)
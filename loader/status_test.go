@@ -0,0 +1,62 @@
+package loader
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatus_RecordRun(t *testing.T) {
+	s := NewStatus()
+
+	s.RecordRun("loader.Consul", OutcomeSuccess, 10*time.Millisecond, "42", nil)
+	s.RecordRun("loader.Env", OutcomeError, 5*time.Millisecond, "", errors.New("boom"))
+
+	snapshot := s.Snapshot()
+	runs, ok := snapshot["runs"].([]RunStatus)
+	require.True(t, ok)
+	require.Len(t, runs, 2)
+
+	byLoader := map[string]RunStatus{}
+	for _, run := range runs {
+		byLoader[run.Loader] = run
+	}
+
+	assert.Equal(t, OutcomeSuccess, byLoader["loader.Consul"].Outcome)
+	assert.Equal(t, "42", byLoader["loader.Consul"].Revision)
+	assert.Empty(t, byLoader["loader.Consul"].Error)
+
+	assert.Equal(t, OutcomeError, byLoader["loader.Env"].Outcome)
+	assert.Equal(t, "boom", byLoader["loader.Env"].Error)
+}
+
+func TestStatus_RecordDynamicUpdate(t *testing.T) {
+	s := NewStatus()
+
+	s.RecordDynamicUpdate("loader.Consul", "loglevel", true)
+	s.RecordDynamicUpdate("loader.Consul", "loglevel", false)
+
+	snapshot := s.Snapshot()
+	dynamic, ok := snapshot["dynamic"].([]DynamicStatus)
+	assert.True(t, ok)
+	assert.Len(t, dynamic, 1)
+	assert.False(t, dynamic[0].Up)
+}
+
+func TestStatus_WrapDynamicRunner(t *testing.T) {
+	s := NewStatus()
+
+	runner := s.WrapDynamicRunner("loader.Consul", "loglevel", func(value []byte) error {
+		return nil
+	})
+
+	assert.NoError(t, runner([]byte("debug")))
+
+	snapshot := s.Snapshot()
+	dynamic := snapshot["dynamic"].([]DynamicStatus)
+	assert.Len(t, dynamic, 1)
+	assert.True(t, dynamic[0].Up)
+}
@@ -0,0 +1,220 @@
+package loader
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"text/template"
+	"time"
+
+	"gitlab.test.igdcs.com/finops/nextgen/utils/basics/igconfig.git/v2/codec"
+
+	"github.com/hashicorp/consul/api"
+)
+
+var _ Loader = Template{}
+
+var _ DynamicValuer = Template{}
+
+// Template is a consul-template-like loader: it renders a Go text/template file using
+// values pulled from the already-configured Consul and Vault loaders, then decodes the
+// rendered output into the target struct the same way File does.
+//
+// Template functions available inside the rendered file:
+//
+//	key path                 - value of a Consul KV key, error if missing
+//	keyOrDefault path def    - value of a Consul KV key, or def if missing
+//	service name [tag...]    - "host:port" list of healthy instances of a service
+//	secret path              - Data map of a Vault secret
+//	env name                 - value of an environment variable
+//
+// Example usage:
+//
+//	var config Config // some Config struct
+//
+//	tplLoader := Template{Path: "/etc/myapp/config.yaml.tmpl", Consul: &consulLoader, Vault: &vaultLoader}
+//	err := tplLoader.Load("adm0001s", &config)
+//	if err != nil { ... }
+type Template struct {
+	// Path to the template file to render.
+	Path string
+	// Decoder specifies function that will decode the rendered output.
+	// By default it is YAML parser.
+	Decoder codec.Decoder
+	// Consul is used to back the 'key', 'keyOrDefault' and 'service' template functions.
+	// If nil, 'key' and 'service' return an error when called and 'keyOrDefault'
+	// returns its default.
+	Consul *Consul
+	// Vault is used to back the 'secret' template function.
+	// If nil, that function returns an error when called.
+	Vault *Vault
+}
+
+// LoadWithContext renders the template and decodes the result into 'to' struct.
+func (t Template) LoadWithContext(ctx context.Context, appName string, to interface{}) error {
+	data, err := t.render(ctx, appName)
+	if err != nil {
+		return fmt.Errorf("Template.LoadWithContext error: %w", err)
+	}
+
+	if t.Decoder == nil {
+		t.Decoder = codec.YAML{}
+	}
+
+	if err := codec.LoadReaderWithDecoder(bytes.NewReader(data), to, t.Decoder, ConsulTag); err != nil {
+		return fmt.Errorf("Template.LoadWithContext error: %w", err)
+	}
+
+	return nil
+}
+
+// Load is just same as LoadWithContext without context.
+func (t Template) Load(appName string, to interface{}) error {
+	return t.LoadWithContext(context.Background(), appName, to)
+}
+
+// DynamicValue re-renders the template once every config.RefreshInterval and calls
+// config.Runner with the rendered output whenever it differs from the previous render,
+// so that dependency changes (a Consul key, a service roster, a Vault secret) propagate
+// without the caller having to reload configuration manually.
+func (t Template) DynamicValue(ctx context.Context, config DynamicConfig) error {
+	interval := config.RefreshInterval
+	if interval <= 0 {
+		interval = 30 * time.Second
+	}
+
+	runner := config.Runner
+	if config.Status != nil {
+		runner = config.Status.WrapDynamicRunner(fmt.Sprintf("%T", t), config.FieldName, runner)
+		// The watch is only reported up while this function is running; mark it down
+		// however it ends, not just when the runner itself errors.
+		defer config.Status.RecordDynamicUpdate(fmt.Sprintf("%T", t), config.FieldName, false)
+	}
+
+	var last []byte
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		data, err := t.render(ctx, config.AppName)
+		if err != nil {
+			return fmt.Errorf("Template.DynamicValue render error: %w", err)
+		}
+
+		if !bytes.Equal(data, last) {
+			last = data
+
+			if err := runner(data); err != nil {
+				return err
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// render reads and executes the template file, returning the rendered output.
+func (t Template) render(ctx context.Context, appName string) ([]byte, error) {
+	raw, err := os.ReadFile(t.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	tmpl, err := template.New(t.Path).Funcs(t.funcMap(ctx, appName)).Parse(string(raw))
+	if err != nil {
+		return nil, fmt.Errorf("parse template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, nil); err != nil {
+		return nil, fmt.Errorf("execute template: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// funcMap builds the template.FuncMap backed by this Template's Consul and Vault loaders.
+func (t Template) funcMap(ctx context.Context, appName string) template.FuncMap {
+	return template.FuncMap{
+		"key": func(keyPath string) (string, error) {
+			if t.Consul == nil {
+				return "", ErrNoClient
+			}
+
+			if err := t.Consul.EnsureClient(); err != nil {
+				return "", err
+			}
+
+			kv, _, err := t.Consul.Client.KV().Get(getConsulConfigPath(appName, keyPath), (&api.QueryOptions{}).WithContext(ctx))
+			if err != nil {
+				return "", err
+			}
+
+			if kv == nil {
+				return "", fmt.Errorf("key %q: %w", keyPath, ErrNoConfFile)
+			}
+
+			return string(kv.Value), nil
+		},
+		"keyOrDefault": func(keyPath, def string) string {
+			if t.Consul == nil {
+				return def
+			}
+
+			if err := t.Consul.EnsureClient(); err != nil {
+				return def
+			}
+
+			kv, _, err := t.Consul.Client.KV().Get(getConsulConfigPath(appName, keyPath), (&api.QueryOptions{}).WithContext(ctx))
+			if err != nil || kv == nil {
+				return def
+			}
+
+			return string(kv.Value)
+		},
+		"service": func(name string, tags ...string) ([]string, error) {
+			if t.Consul == nil {
+				return nil, ErrNoClient
+			}
+
+			services, err := t.Consul.SearchLiveServices(ctx, name, tags)
+			if err != nil {
+				return nil, err
+			}
+
+			addrs := make([]string, 0, len(services))
+			for _, svc := range services {
+				addrs = append(addrs, serviceHostPort(svc))
+			}
+
+			return addrs, nil
+		},
+		"secret": func(secretPath string) (map[string]interface{}, error) {
+			if t.Vault == nil {
+				return nil, ErrNoClient
+			}
+
+			if err := t.Vault.EnsureClient(); err != nil {
+				return nil, err
+			}
+
+			secret, err := t.Vault.Client.Logical().ReadWithContext(ctx, getVaultSecretPath(appName, secretPath))
+			if err != nil {
+				return nil, err
+			}
+
+			if secret == nil {
+				return nil, fmt.Errorf("secret %q: %w", secretPath, ErrNoConfFile)
+			}
+
+			return secret.Data, nil
+		},
+		"env": os.Getenv,
+	}
+}
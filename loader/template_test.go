@@ -0,0 +1,85 @@
+package loader
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplate_LoadWithContext(t *testing.T) {
+	type res struct {
+		Name string
+		Env  string
+	}
+
+	dir := t.TempDir()
+	tplPath := filepath.Join(dir, "config.yaml.tmpl")
+
+	require.NoError(t, os.WriteFile(tplPath, []byte("name: static\nenv: {{ env \"IGCONFIG_TEST_ENV\" }}\n"), 0o600))
+	t.Setenv("IGCONFIG_TEST_ENV", "from-env")
+
+	tpl := Template{Path: tplPath}
+
+	var to res
+
+	require.NoError(t, tpl.Load("app", &to))
+	assert.Equal(t, res{Name: "static", Env: "from-env"}, to)
+}
+
+func TestTemplate_LoadWithContext_MissingKeyErrors(t *testing.T) {
+	dir := t.TempDir()
+	tplPath := filepath.Join(dir, "config.yaml.tmpl")
+
+	require.NoError(t, os.WriteFile(tplPath, []byte("name: {{ key \"missing\" }}\n"), 0o600))
+
+	tpl := Template{Path: tplPath, Consul: &Consul{Client: NewConsulMock(ConsulMock{kv: map[string][]byte{}})}}
+
+	var to struct{ Name string }
+
+	err := tpl.Load("app", &to)
+	assert.Error(t, err)
+}
+
+func TestTemplate_DynamicValue_SkipsUnchangedRenders(t *testing.T) {
+	dir := t.TempDir()
+	tplPath := filepath.Join(dir, "config.yaml.tmpl")
+
+	require.NoError(t, os.WriteFile(tplPath, []byte("name: static\n"), 0o600))
+
+	tpl := Template{Path: tplPath}
+	status := NewStatus()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	var calls int
+
+	err := tpl.DynamicValue(ctx, DynamicConfig{
+		AppName:         "app",
+		FieldName:       "field",
+		RefreshInterval: time.Millisecond,
+		Status:          status,
+		Runner: func(value []byte) error {
+			calls++
+
+			return nil
+		},
+	})
+
+	assert.Equal(t, context.DeadlineExceeded, err)
+	assert.Equal(t, 1, calls)
+
+	// DynamicValue returned (the context expired), so the watch is no longer running
+	// and must be reported down - LastUpdateTime proves it was marked up earlier.
+	dynamic := status.Snapshot()["dynamic"].([]DynamicStatus)
+	require.Len(t, dynamic, 1)
+	assert.Equal(t, "loader.Template", dynamic[0].Loader)
+	assert.Equal(t, "field", dynamic[0].FieldName)
+	assert.False(t, dynamic[0].Up)
+	assert.False(t, dynamic[0].LastUpdateTime.IsZero())
+}
@@ -0,0 +1,274 @@
+package loader
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+
+	"gitlab.test.igdcs.com/finops/nextgen/utils/basics/igconfig.git/v2/codec"
+
+	"github.com/hashicorp/vault/api"
+	"github.com/rs/zerolog/log"
+)
+
+var VaultTag = "cfg"
+
+// VaultConfigPathPrefix specifies prefix for secret path lookup.
+var VaultConfigPathPrefix = "secret/finops"
+
+var _ Loader = &Vault{}
+
+var _ DynamicValuer = &Vault{}
+
+var _ StatusReporter = &Vault{}
+
+// Vault is an instance of configuration loader from Vault.
+//
+// Example usage:
+//
+//	var config Config // some Config struct
+//
+//	cl, err := api.NewClient(&api.Config{Address: "http://vault:8200"})
+//	if err != nil { ... }
+//
+//	vaultLoader := Vault{Client: cl}
+//	err = vaultLoader.Load("adm0001s", &config)
+//	if err != nil { ... }
+//
+//	// config is now populated from Vault.
+type Vault struct {
+	Client *api.Client
+	// Decoder specifies function that will decode the secret's Data into 'to'.
+	// By default it is JSON, since Vault secrets are returned as a map[string]interface{}.
+	//
+	// Note: this function is not used in DynamicValue.
+	Decoder codec.Decoder
+
+	// lastRevision is the LeaseID of the secret last successfully loaded, set by
+	// LoadWithContext and reported via LastRevision.
+	lastRevision string
+}
+
+// LoadWithContext retrieves a secret from Vault and decodes its Data into 'to' struct.
+func (v *Vault) LoadWithContext(ctx context.Context, appName string, to interface{}) error {
+	if err := v.EnsureClient(); err != nil {
+		return err
+	}
+
+	secret, err := v.Client.Logical().ReadWithContext(ctx, getVaultSecretPath(appName))
+	// If no data or err is returned - return early.
+	if secret == nil || err != nil {
+		return err
+	}
+
+	if v.Decoder == nil {
+		v.Decoder = codec.JSON{}
+	}
+
+	data, err := json.Marshal(secret.Data)
+	if err != nil {
+		return fmt.Errorf("Vault.LoadWithContext marshal error: %w", err)
+	}
+
+	if err := codec.LoadReaderWithDecoder(bytes.NewReader(data), to, v.Decoder, VaultTag); err != nil {
+		return fmt.Errorf("Vault.LoadWithContext error: %w", err)
+	}
+
+	v.lastRevision = secret.LeaseID
+
+	return nil
+}
+
+// LastRevision returns the LeaseID of the secret last successfully loaded, or "" if
+// LoadWithContext has not yet succeeded or the secret has no lease (e.g. static KV data).
+func (v *Vault) LastRevision() string {
+	return v.lastRevision
+}
+
+// Load is just same as LoadWithContext without context.
+func (v *Vault) Load(appName string, to interface{}) error {
+	return v.LoadWithContext(context.Background(), appName, to)
+}
+
+// DynamicValue allows callers to be notified when a Vault secret is rotated.
+//
+// WARNING: this is experimental feature and is not guaranteed to work. Also it could be changed at will.
+//
+// ---
+//
+// If the secret at config.FieldName's path is renewable (Secret.Auth.Renewable or Secret.Renewable),
+// a background renewer is spawned using api.LifetimeWatcher, configured with
+// RenewBehavior: RenewBehaviorIgnoreErrors so transient renewal failures don't tear down the watcher.
+// Increment is computed from the secret's own TTL (Auth.LeaseDuration or LeaseDuration).
+//
+// config.Runner is called with the freshly re-read secret whenever its Data actually
+// changes - a plain lease renewal just extends the same lease's TTL without returning
+// fresh Data, so renewals that don't change Data are not reported, the same way
+// Consul.DynamicValue only calls its runner on an actual key change.
+//
+// This function will spin up a goroutine to track renewals in background, while this function will
+// still be blocking, so errors from it can be observed the same way they are surfaced for Consul.
+func (v *Vault) DynamicValue(ctx context.Context, config DynamicConfig) error {
+	if err := v.EnsureClient(); err != nil {
+		return err
+	}
+
+	runner := config.Runner
+	if config.Status != nil {
+		runner = config.Status.WrapDynamicRunner(fmt.Sprintf("%T", v), config.FieldName, runner)
+		// The watch is only reported up while this function is running; mark it down
+		// however it ends, not just when the runner itself errors.
+		defer config.Status.RecordDynamicUpdate(fmt.Sprintf("%T", v), config.FieldName, false)
+	}
+
+	secretPath := getVaultSecretPath(config.AppName, config.FieldName)
+
+	secret, err := v.Client.Logical().ReadWithContext(ctx, secretPath)
+	if err != nil {
+		return err
+	}
+
+	if secret == nil || !isRenewable(secret) {
+		return executeVaultRunner(ctx, secretPath, secret, runner)
+	}
+
+	watcher, err := v.Client.NewLifetimeWatcher(&api.LifetimeWatcherInput{
+		Secret:        secret,
+		Increment:     leaseIncrement(secret),
+		RenewBehavior: api.RenewBehaviorIgnoreErrors,
+	})
+	if err != nil {
+		return fmt.Errorf("create lifetime watcher: %w", err)
+	}
+
+	watchCtx, stopWatcher := context.WithCancel(ctx)
+	defer stopWatcher()
+
+	go watcher.Start()
+	defer watcher.Stop()
+
+	lastData, err := marshalVaultSecretData(secret)
+	if err != nil {
+		return err
+	}
+
+	if execErr := runner(lastData); execErr != nil {
+		return execErr
+	}
+
+	for {
+		select {
+		case <-watchCtx.Done():
+			return watchCtx.Err()
+		case err := <-watcher.DoneCh():
+			if err != nil {
+				return fmt.Errorf("renew lease %s: %w", secretPath, err)
+			}
+
+			return nil
+		case <-watcher.RenewCh():
+			fresh, err := v.Client.Logical().ReadWithContext(watchCtx, secretPath)
+			if err != nil {
+				return fmt.Errorf("re-read secret %s: %w", secretPath, err)
+			}
+
+			data, err := marshalVaultSecretData(fresh)
+			if err != nil {
+				return err
+			}
+
+			if bytes.Equal(data, lastData) {
+				continue
+			}
+
+			lastData = data
+
+			if execErr := runner(data); execErr != nil {
+				return execErr
+			}
+		}
+	}
+}
+
+// EnsureClient creates and sets a Vault client if needed.
+func (v *Vault) EnsureClient() error {
+	if v.Client == nil {
+		var err error
+
+		v.Client, err = NewVaultFromEnv()
+		if err != nil {
+			return err
+		}
+	}
+
+	if v.Client == nil {
+		return ErrNoClient
+	}
+
+	return nil
+}
+
+// NewVaultFromEnv creates a client from environmental variables.
+//
+// This function uses api.DefaultConfig(), which means that variables should be named as Vault expects them,
+// e.g. VAULT_ADDR and VAULT_TOKEN.
+func NewVaultFromEnv() (*api.Client, error) {
+	if _, ok := os.LookupEnv("VAULT_ADDR"); !ok {
+		return nil, fmt.Errorf("VAULT_ADDR not exist, err: %w", ErrNoClient)
+	}
+
+	cl, err := api.NewClient(api.DefaultConfig())
+	if cl == nil {
+		return nil, ErrNoClient
+	}
+
+	return cl, err
+}
+
+func getVaultSecretPath(parts ...string) string {
+	return path.Join(append([]string{VaultConfigPathPrefix}, parts...)...)
+}
+
+func isRenewable(secret *api.Secret) bool {
+	if secret.Auth != nil {
+		return secret.Auth.Renewable
+	}
+
+	return secret.Renewable
+}
+
+func leaseIncrement(secret *api.Secret) int {
+	if secret.Auth != nil {
+		return secret.Auth.LeaseDuration
+	}
+
+	return secret.LeaseDuration
+}
+
+// marshalVaultSecretData returns secret.Data as JSON, or nil if secret itself is nil.
+func marshalVaultSecretData(secret *api.Secret) ([]byte, error) {
+	if secret == nil {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(secret.Data)
+	if err != nil {
+		return nil, fmt.Errorf("marshal secret data: %w", err)
+	}
+
+	return data, nil
+}
+
+func executeVaultRunner(ctx context.Context, secretPath string, secret *api.Secret, runner DynamicRunner) error {
+	log.Ctx(ctx).Debug().Str("secret_path", secretPath).Msg("new dynamic secret received")
+
+	data, err := marshalVaultSecretData(secret)
+	if err != nil {
+		return err
+	}
+
+	return runner(data)
+}
@@ -0,0 +1,139 @@
+package loader
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"path"
+	"strings"
+	"testing"
+
+	vaultapi "github.com/hashicorp/vault/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVault_LoadWithContext(t *testing.T) {
+	type res struct {
+		UntaggedStr  string
+		CamelCaseStr string `cfg:"camelCaseStr"`
+	}
+
+	tests := []struct {
+		name      string
+		vaultConf VaultMock
+		to        res
+		result    res
+		revision  string
+	}{
+		{
+			name: "test-secret",
+			vaultConf: VaultMock{secrets: map[string]*vaultapi.Secret{
+				"test-secret": {
+					LeaseID: "lease-1",
+					Data: map[string]interface{}{
+						"untaggedStr":  "untag value",
+						"camelCaseStr": "camel case value",
+					},
+				},
+			}},
+			result: res{
+				UntaggedStr:  "untag value",
+				CamelCaseStr: "camel case value",
+			},
+			revision: "lease-1",
+		},
+		{
+			name:      "no-secret",
+			vaultConf: VaultMock{secrets: map[string]*vaultapi.Secret{}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			vault := Vault{Client: NewVaultMock(test.vaultConf)}
+
+			err := vault.Load(test.name, &test.to)
+
+			require.NoError(t, err)
+			assert.Equal(t, test.result, test.to)
+			assert.Equal(t, test.revision, vault.LastRevision())
+		})
+	}
+}
+
+func TestVault_DynamicValue_NonRenewable(t *testing.T) {
+	vault := Vault{Client: NewVaultMock(VaultMock{secrets: map[string]*vaultapi.Secret{
+		"app/field": {Data: map[string]interface{}{"key": "value"}},
+	}})}
+
+	status := NewStatus()
+
+	var got []byte
+
+	err := vault.DynamicValue(context.Background(), DynamicConfig{
+		AppName:   "app",
+		FieldName: "field",
+		Status:    status,
+		Runner: func(value []byte) error {
+			got = value
+
+			return nil
+		},
+	})
+
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"key":"value"}`, string(got))
+
+	// DynamicValue returned, so the watch is no longer running and must be reported
+	// down - but LastUpdateTime proves it was marked up while the runner executed.
+	dynamic := status.Snapshot()["dynamic"].([]DynamicStatus)
+	require.Len(t, dynamic, 1)
+	assert.Equal(t, "*loader.Vault", dynamic[0].Loader)
+	assert.Equal(t, "field", dynamic[0].FieldName)
+	assert.False(t, dynamic[0].Up)
+	assert.False(t, dynamic[0].LastUpdateTime.IsZero())
+}
+
+func NewVaultMock(mockConfig VaultMock) *vaultapi.Client {
+	cl, _ := vaultapi.NewClient(&vaultapi.Config{
+		Address: "http://vault.invalid",
+		HttpClient: &http.Client{
+			Transport: mockConfig,
+		},
+	})
+
+	return cl
+}
+
+type VaultMock struct {
+	secrets map[string]*vaultapi.Secret
+}
+
+func (m VaultMock) RoundTrip(request *http.Request) (*http.Response, error) {
+	key := strings.TrimPrefix(path.Clean(request.URL.Path), "/v1/"+VaultConfigPathPrefix+"/")
+
+	secret, ok := m.secrets[key]
+	if !ok {
+		return &http.Response{
+			StatusCode: http.StatusNotFound,
+			Body:       ioutil.NopCloser(bytes.NewReader([]byte("{}"))),
+			Header:     http.Header{},
+			Request:    request,
+		}, nil
+	}
+
+	bts, err := json.Marshal(secret)
+	if err != nil {
+		return nil, err
+	}
+
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       ioutil.NopCloser(bytes.NewReader(bts)),
+		Header:     http.Header{},
+		Request:    request,
+	}, nil
+}
@@ -0,0 +1,190 @@
+package igconfig
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"gitlab.test.igdcs.com/finops/nextgen/utils/basics/igconfig.git/v2/loader"
+)
+
+// MergeStrategy combines the values a loader just produced into the config struct
+// accumulated from previous loaders.
+type MergeStrategy interface {
+	// Merge combines src into dst. Both must be pointers to the same struct type.
+	Merge(dst, src interface{}) error
+}
+
+// DefaultMergeStrategy is used by LoadWithLoadersWithContext to combine each loader's
+// output into the config struct. It can be overridden by callers that need different
+// merge semantics.
+var DefaultMergeStrategy MergeStrategy = DeepMergeStrategy{}
+
+// DeepMergeStrategy is a MergeStrategy in the spirit of mergo.MergeWithOverwrite: it
+// deep-merges structs and maps field by field instead of replacing whole subtrees, and
+// it keeps a value already set by an earlier loader when a later loader leaves that
+// field at its zero value.
+//
+// Slice fields honor a `cfg:"...,mergestrategy=append|replace|preserve"` tag:
+//
+//	replace  - src replaces dst entirely (the default, current LoadWithLoadersWithContext behavior)
+//	append   - src is appended onto dst
+//	preserve - dst is kept as-is if it already has a value
+type DeepMergeStrategy struct{}
+
+// Merge deep-merges src into dst. dst and src must both be non-nil pointers to the
+// same struct type.
+func (DeepMergeStrategy) Merge(dst, src interface{}) error {
+	dstVal := reflect.ValueOf(dst)
+	srcVal := reflect.ValueOf(src)
+
+	if dstVal.Kind() != reflect.Ptr || srcVal.Kind() != reflect.Ptr {
+		return fmt.Errorf("DeepMergeStrategy.Merge: dst and src must be pointers, got %T and %T", dst, src)
+	}
+
+	if dstVal.IsNil() || srcVal.IsNil() {
+		return fmt.Errorf("DeepMergeStrategy.Merge: dst and src must not be nil")
+	}
+
+	mergeStruct(dstVal.Elem(), srcVal.Elem())
+
+	return nil
+}
+
+// mergeStruct merges every exported field of src into the matching field of dst.
+//
+// Unexported fields are skipped entirely: they're not Set-able via reflection, and
+// attempting to recurse into them (or into a struct field that has unexported fields
+// of its own) panics.
+func mergeStruct(dst, src reflect.Value) {
+	t := dst.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+
+		mergeField(dst.Field(i), src.Field(i), mergeStrategyTag(field))
+	}
+}
+
+// mergeField merges a single field, dispatching on its kind.
+func mergeField(dst, src reflect.Value, strategy string) {
+	// Structs with unexported fields of their own (time.Time, sync.Mutex, ...) can't be
+	// merged field by field - treat them as opaque leaf values instead.
+	if dst.Kind() == reflect.Struct && isLeafStruct(dst.Type()) {
+		mergeLeaf(dst, src, strategy)
+		return
+	}
+
+	switch dst.Kind() {
+	case reflect.Struct:
+		mergeStruct(dst, src)
+	case reflect.Map:
+		mergeMap(dst, src)
+	case reflect.Slice:
+		mergeSlice(dst, src, strategy)
+	default:
+		mergeLeaf(dst, src, strategy)
+	}
+}
+
+// mergeLeaf overwrites dst with src wholesale, honoring the "preserve" strategy.
+func mergeLeaf(dst, src reflect.Value, strategy string) {
+	if strategy == "preserve" && !dst.IsZero() {
+		return
+	}
+
+	if !src.IsZero() {
+		dst.Set(src)
+	}
+}
+
+// isLeafStruct reports whether t has any unexported field of its own (an unexported
+// embedded field counts), making it unsafe to merge field by field via reflection.
+// Fields of embedded structs are not inspected here.
+func isLeafStruct(t reflect.Type) bool {
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).PkgPath != "" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// mergeSlice merges src into dst according to strategy, defaulting to "replace".
+func mergeSlice(dst, src reflect.Value, strategy string) {
+	if src.IsNil() {
+		return
+	}
+
+	switch strategy {
+	case "append":
+		dst.Set(reflect.AppendSlice(dst, src))
+	case "preserve":
+		if dst.IsNil() {
+			dst.Set(src)
+		}
+	default:
+		dst.Set(src)
+	}
+}
+
+// mergeMap merges src's entries into dst, overwriting individual keys but never
+// wiping sibling entries dst already has. Nested maps are merged recursively.
+func mergeMap(dst, src reflect.Value) {
+	if src.IsNil() {
+		return
+	}
+
+	if dst.IsNil() {
+		dst.Set(reflect.MakeMap(dst.Type()))
+	}
+
+	iter := src.MapRange()
+	for iter.Next() {
+		key, srcEntry := iter.Key(), iter.Value()
+
+		// Maps held in interface{} values (as decoded from YAML/JSON) are unwrapped so
+		// they are merged too, not just maps whose static type is a map.
+		dstEntry, srcInner := dst.MapIndex(key), srcEntry
+		if dstEntry.IsValid() && dstEntry.Kind() == reflect.Interface {
+			dstEntry = dstEntry.Elem()
+		}
+
+		if srcInner.Kind() == reflect.Interface {
+			srcInner = srcInner.Elem()
+		}
+
+		if dstEntry.IsValid() && dstEntry.Kind() == reflect.Map && srcInner.IsValid() && srcInner.Kind() == reflect.Map &&
+			dstEntry.Type() == srcInner.Type() {
+			merged := reflect.MakeMap(dstEntry.Type())
+			mergeMap(merged, dstEntry)
+			mergeMap(merged, srcInner)
+			dst.SetMapIndex(key, merged)
+
+			continue
+		}
+
+		dst.SetMapIndex(key, srcEntry)
+	}
+}
+
+// mergeStrategyTag reads the `mergestrategy=` part of field's loader.ConsulTag (e.g. "cfg"),
+// returning "" (replace) if not set.
+func mergeStrategyTag(field reflect.StructField) string {
+	tagVal, ok := field.Tag.Lookup(loader.ConsulTag)
+	if !ok {
+		return ""
+	}
+
+	for _, part := range strings.Split(tagVal, ",") {
+		if strategy := strings.TrimPrefix(part, "mergestrategy="); strategy != part {
+			return strategy
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,122 @@
+package igconfig
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gitlab.test.igdcs.com/finops/nextgen/utils/basics/igconfig.git/v2/loader"
+)
+
+func TestDeepMergeStrategy_Merge(t *testing.T) {
+	type inner struct {
+		Slice []string `cfg:"slice,mergestrategy=append"`
+		Value string
+	}
+	type cfg struct {
+		Name    string
+		Port    int
+		Created time.Time
+		Tags    []string `cfg:"tags,mergestrategy=preserve"`
+		Labels  map[string]string
+		Inner   inner
+	}
+
+	created := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		dst  cfg
+		src  cfg
+		want cfg
+	}{
+		{
+			name: "zero src fields don't overwrite dst",
+			dst:  cfg{Name: "from-earlier-loader", Port: 8080},
+			src:  cfg{Port: 0},
+			want: cfg{Name: "from-earlier-loader", Port: 8080},
+		},
+		{
+			name: "non-zero src fields overwrite dst",
+			dst:  cfg{Name: "old"},
+			src:  cfg{Name: "new", Port: 9090},
+			want: cfg{Name: "new", Port: 9090},
+		},
+		{
+			name: "time.Time is treated as an opaque leaf, not recursed into",
+			dst:  cfg{},
+			src:  cfg{Created: created},
+			want: cfg{Created: created},
+		},
+		{
+			name: "slice mergestrategy=append appends src onto dst",
+			dst:  cfg{Inner: inner{Slice: []string{"a"}}},
+			src:  cfg{Inner: inner{Slice: []string{"b"}}},
+			want: cfg{Inner: inner{Slice: []string{"a", "b"}}},
+		},
+		{
+			name: "slice mergestrategy=preserve keeps dst when already set",
+			dst:  cfg{Tags: []string{"keep"}},
+			src:  cfg{Tags: []string{"discard"}},
+			want: cfg{Tags: []string{"keep"}},
+		},
+		{
+			name: "slice mergestrategy=preserve takes src when dst unset",
+			dst:  cfg{},
+			src:  cfg{Tags: []string{"new"}},
+			want: cfg{Tags: []string{"new"}},
+		},
+		{
+			name: "maps merge key by key without wiping sibling entries",
+			dst:  cfg{Labels: map[string]string{"a": "1", "b": "2"}},
+			src:  cfg{Labels: map[string]string{"b": "overridden", "c": "3"}},
+			want: cfg{Labels: map[string]string{"a": "1", "b": "overridden", "c": "3"}},
+		},
+		{
+			name: "nested struct fields merge individually",
+			dst:  cfg{Inner: inner{Value: "old"}},
+			src:  cfg{Inner: inner{}},
+			want: cfg{Inner: inner{Value: "old"}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dst := test.dst
+
+			err := DeepMergeStrategy{}.Merge(&dst, &test.src)
+
+			require.NoError(t, err)
+			assert.Equal(t, test.want, dst)
+		})
+	}
+}
+
+func TestDeepMergeStrategy_Merge_RequiresPointers(t *testing.T) {
+	var dst, src struct{}
+
+	err := DeepMergeStrategy{}.Merge(dst, &src)
+	assert.Error(t, err)
+
+	err = DeepMergeStrategy{}.Merge(&dst, src)
+	assert.Error(t, err)
+}
+
+func TestDeepMergeStrategy_Merge_HonorsCustomConsulTag(t *testing.T) {
+	type cfg struct {
+		Tags []string `myTag:"tags,mergestrategy=preserve"`
+	}
+
+	defer func(orig string) { loader.ConsulTag = orig }(loader.ConsulTag)
+	loader.ConsulTag = "myTag"
+
+	dst := cfg{Tags: []string{"keep"}}
+	src := cfg{Tags: []string{"discard"}}
+
+	err := DeepMergeStrategy{}.Merge(&dst, &src)
+
+	require.NoError(t, err)
+	assert.Equal(t, cfg{Tags: []string{"keep"}}, dst)
+}
@@ -0,0 +1,23 @@
+package igconfig
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// StatusHandler returns an http.Handler that serves DefaultStatus as JSON, giving
+// operators a way to check "is my config source healthy?" the same way Nomad/Consul
+// expose health of their own subsystems.
+//
+// Prometheus metrics (igconfig_loader_loads_total, igconfig_loader_last_success_timestamp_seconds,
+// igconfig_dynamic_watch_up) are registered automatically and served by whatever promhttp
+// handler the application already exposes.
+func StatusHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if err := json.NewEncoder(w).Encode(DefaultStatus.Snapshot()); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+}